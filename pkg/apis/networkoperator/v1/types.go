@@ -0,0 +1,165 @@
+// Package v1 contains the API types for the cluster network operator's
+// NetworkConfig custom resource.
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkConfig is the CRD through which cluster administrators configure
+// the cluster-wide network. There is a single, singleton instance named
+// "cluster".
+type NetworkConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkConfigSpec `json:"spec"`
+}
+
+// NetworkConfigSpec is the top-level network configuration.
+type NetworkConfigSpec struct {
+	// ServiceNetwork is the CIDR that service ClusterIPs are allocated from.
+	ServiceNetwork string `json:"serviceNetwork"`
+
+	// ClusterNetworks holds the CIDR(s) that pod IPs are allocated from, and
+	// how they are subdivided per node.
+	ClusterNetworks []ClusterNetwork `json:"clusterNetworks"`
+
+	// DefaultNetwork selects and configures the pod network implementation.
+	DefaultNetwork DefaultNetworkDefinition `json:"defaultNetwork"`
+
+	// DeployKubeProxy indicates whether the operator should deploy kube-proxy.
+	// It defaults to false for network plugins, such as openshift-sdn, that
+	// include their own kube-proxy-equivalent.
+	// +optional
+	DeployKubeProxy *bool `json:"deployKubeProxy,omitempty"`
+
+	// KubeProxyConfig lets the user tweak the kube-proxy configuration, or
+	// the equivalent configuration of the default network's built-in proxy.
+	// +optional
+	KubeProxyConfig *ProxyConfig `json:"kubeProxyConfig,omitempty"`
+}
+
+// ClusterNetwork describes a single pod IP range and how it is split up
+// into per-node subnets.
+type ClusterNetwork struct {
+	CIDR string `json:"cidr"`
+
+	// HostSubnetLength is the number of bits of the CIDR that are allocated
+	// to each node for its local pod subnet.
+	HostSubnetLength uint32 `json:"hostSubnetLength"`
+}
+
+// NetworkType identifies a supported default network plugin.
+type NetworkType string
+
+const (
+	// NetworkTypeOpenShiftSDN selects the openshift-sdn plugin.
+	NetworkTypeOpenShiftSDN NetworkType = "OpenShiftSDN"
+
+	// NetworkTypeOVNKubernetes selects the ovn-kubernetes plugin.
+	NetworkTypeOVNKubernetes NetworkType = "OVNKubernetes"
+)
+
+// DefaultNetworkDefinition selects and configures the cluster's default pod
+// network. Exactly one of the Config fields should be set, matching Type.
+type DefaultNetworkDefinition struct {
+	Type NetworkType `json:"type"`
+
+	// +optional
+	OpenShiftSDNConfig *OpenShiftSDNConfig `json:"openshiftSDNConfig,omitempty"`
+
+	// +optional
+	OVNKubernetesConfig *OVNKubernetesConfig `json:"ovnKubernetesConfig,omitempty"`
+}
+
+// SDNMode is the openshift-sdn isolation mode.
+type SDNMode string
+
+const (
+	SDNModeSubnet        SDNMode = "Subnet"
+	SDNModeMultitenant   SDNMode = "Multitenant"
+	SDNModeNetworkPolicy SDNMode = "NetworkPolicy"
+)
+
+// OpenShiftSDNConfig configures the openshift-sdn default network plugin.
+type OpenShiftSDNConfig struct {
+	// Mode is one of "Subnet", "Multitenant", or "NetworkPolicy".
+	Mode SDNMode `json:"mode"`
+
+	// VXLANPort is the port to use for the VXLAN overlay. Defaults to 4789.
+	// +optional
+	VXLANPort *uint32 `json:"vxlanPort,omitempty"`
+
+	// MTU is the MTU to use for the SDN overlay network. Defaults to the
+	// node's primary interface MTU minus overhead for the encapsulation
+	// used (currently 50 bytes, for VXLAN).
+	// +optional
+	MTU *uint32 `json:"mtu,omitempty"`
+
+	// UseExternalOpenvswitch tells the operator not to install its own OVS
+	// DaemonSet, because the platform (e.g. RHCOS) already runs one.
+	// +optional
+	UseExternalOpenvswitch *bool `json:"useExternalOpenvswitch,omitempty"`
+
+	// EnableUnidling controls whether the sdn proxy runs in unidling mode,
+	// which lets services that have been scaled to zero be woken back up by
+	// incoming traffic. Defaults to true; set to false to use the plain
+	// iptables proxy.
+	// +optional
+	EnableUnidling *bool `json:"enableUnidling,omitempty"`
+
+	// OVSHealthCheck configures the periodic liveness probe openshift-sdn
+	// runs against the local ovsdb-server, which restarts the ovs container
+	// when it stops responding.
+	// +optional
+	OVSHealthCheck *OVSHealthCheck `json:"ovsHealthCheck,omitempty"`
+}
+
+// OVSHealthCheck configures the liveness probe openshift-sdn runs against
+// /var/run/openvswitch/db.sock.
+type OVSHealthCheck struct {
+	// IntervalSeconds is how often, in seconds, to probe the OVSDB socket.
+	// Defaults to 30.
+	// +optional
+	IntervalSeconds *uint32 `json:"intervalSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes after
+	// which the ovs container is restarted. Defaults to 3.
+	// +optional
+	FailureThreshold *uint32 `json:"failureThreshold,omitempty"`
+}
+
+// OVNKubernetesConfig configures the ovn-kubernetes default network plugin,
+// which replaces the host's CNI binaries with a thin cnishim that forwards
+// ADD/DEL/CHECK calls over a unix socket to an on-node cniserver owning all
+// OVN plumbing.
+type OVNKubernetesConfig struct {
+	// MTU is the MTU to use for the OVN overlay network. Defaults to the
+	// node's primary interface MTU minus overhead for Geneve encapsulation.
+	// +optional
+	MTU *uint32 `json:"mtu,omitempty"`
+
+	// GenevePort is the UDP port to use for the Geneve overlay. Defaults to
+	// 6081.
+	// +optional
+	GenevePort *uint32 `json:"genevePort,omitempty"`
+}
+
+// ProxyConfig configures kube-proxy (or an equivalent built into the default
+// network plugin).
+type ProxyConfig struct {
+	// IptablesSyncPeriod sets the iptables sync period, e.g. "30s". Defaults
+	// to the proxy's own built-in default when empty.
+	// +optional
+	IptablesSyncPeriod string `json:"iptablesSyncPeriod,omitempty"`
+
+	// BindAddress overrides the address the proxy's metrics/healthz server
+	// listens on.
+	// +optional
+	BindAddress string `json:"bindAddress,omitempty"`
+
+	// ProxyArguments allows for setting custom args for the proxy command.
+	// +optional
+	ProxyArguments map[string][]string `json:"proxyArguments,omitempty"`
+}
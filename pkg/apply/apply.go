@@ -0,0 +1,71 @@
+// Package apply applies rendered Kubernetes manifests to the cluster,
+// reconciling them against whatever is already live.
+package apply
+
+import (
+	"github.com/pkg/errors"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// supportedKinds enumerates the object kinds we know how to merge and
+// compare. Anything else is rejected by IsObjectSupported so a typo'd
+// manifest fails fast instead of silently never reconciling.
+var supportedKinds = map[string]bool{
+	"Namespace":                      true,
+	"ServiceAccount":                 true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"Role":                           true,
+	"RoleBinding":                    true,
+	"ConfigMap":                      true,
+	"Secret":                         true,
+	"Service":                        true,
+	"DaemonSet":                      true,
+	"Deployment":                     true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+// IsObjectSupported returns an error if obj is of a kind that ApplyObject
+// does not know how to reconcile.
+func IsObjectSupported(obj *uns.Unstructured) error {
+	if !supportedKinds[obj.GetKind()] {
+		return errors.Errorf("unsupported object kind %q", obj.GetKind())
+	}
+	return nil
+}
+
+// MergeObjectForUpdate prepares "upd" (the manifest as rendered) to be
+// compared against / applied over "cur" (the object's current live state),
+// by copying over the server-managed metadata fields that we never want to
+// overwrite. It mutates upd in place.
+func MergeObjectForUpdate(cur, upd *uns.Unstructured) error {
+	upd.SetResourceVersion(cur.GetResourceVersion())
+	upd.SetCreationTimestamp(cur.GetCreationTimestamp())
+	upd.SetSelfLink(cur.GetSelfLink())
+	upd.SetUID(cur.GetUID())
+	upd.SetGeneration(cur.GetGeneration())
+	upd.SetAnnotations(mergeStringMaps(cur.GetAnnotations(), upd.GetAnnotations()))
+
+	return nil
+}
+
+// mergeStringMaps returns a new map containing base overlaid with overlay,
+// or nil if the result would be empty. Unstructured.SetAnnotations treats a
+// non-nil empty map differently from nil (it stores a literal `{}` instead
+// of omitting the field), so returning nil here keeps a merged-for-update
+// object identical to one freshly rendered when neither side has any
+// annotations.
+func mergeStringMaps(base, overlay map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overlay {
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// kubernetesIDMatcher matches an *uns.Unstructured against a kind/namespace/
+// name triple, so tests can assert on the rendered object list without
+// caring about its exact position.
+type kubernetesIDMatcher struct {
+	kind, namespace, name string
+}
+
+// HaveKubernetesID returns a gomega matcher for an unstructured object with
+// the given kind, namespace, and name.
+func HaveKubernetesID(kind, namespace, name string) types.GomegaMatcher {
+	return &kubernetesIDMatcher{kind: kind, namespace: namespace, name: name}
+}
+
+func (m *kubernetesIDMatcher) Match(actual interface{}) (bool, error) {
+	obj, ok := actual.(*uns.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("HaveKubernetesID expects an *unstructured.Unstructured, got %T", actual)
+	}
+
+	return obj.GetKind() == m.kind &&
+		obj.GetNamespace() == m.namespace &&
+		obj.GetName() == m.name, nil
+}
+
+func (m *kubernetesIDMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("expected object matching kind=%s namespace=%s name=%s, got %#v", m.kind, m.namespace, m.name, actual)
+}
+
+func (m *kubernetesIDMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("expected no object matching kind=%s namespace=%s name=%s, got %#v", m.kind, m.namespace, m.name, actual)
+}
+
+// tweakMetaForCompare strips metadata fields that apply.MergeObjectForUpdate
+// intentionally leaves untouched when the object didn't have them to begin
+// with (e.g. an empty creationTimestamp), so that comparing a freshly
+// rendered object against itself after a merge round-trip reports equal.
+func tweakMetaForCompare(obj *uns.Unstructured) {
+	meta, found, _ := uns.NestedMap(obj.Object, "metadata")
+	if !found {
+		return
+	}
+
+	if ts, ok := meta["creationTimestamp"]; ok && ts == nil {
+		delete(meta, "creationTimestamp")
+		_ = uns.SetNestedMap(obj.Object, meta, "metadata")
+	}
+}
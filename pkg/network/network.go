@@ -0,0 +1,74 @@
+// Package network implements the cluster-network-operator's rendering,
+// validation, and live-upgrade-safety logic for the supported default
+// network plugins.
+package network
+
+import (
+	"github.com/pkg/errors"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultHostMTU is the MTU assumed for the node's primary interface when it
+// cannot otherwise be determined. 1500 is the common Ethernet default.
+const defaultHostMTU = uint32(1500)
+
+// Render turns conf into the full set of objects the operator should apply
+// for the cluster's default network, dispatching to the plugin-specific
+// renderer selected by conf.DefaultNetwork.Type.
+func Render(conf *netv1.NetworkConfigSpec, manifestDir string) ([]*uns.Unstructured, error) {
+	switch conf.DefaultNetwork.Type {
+	case netv1.NetworkTypeOpenShiftSDN:
+		return renderOpenShiftSDN(conf, manifestDir)
+	case netv1.NetworkTypeOVNKubernetes:
+		return renderOVNKubernetes(conf, manifestDir)
+	default:
+		return nil, errors.Errorf("unknown default network type %q", conf.DefaultNetwork.Type)
+	}
+}
+
+// Validate checks conf for internal consistency, returning every error
+// found rather than stopping at the first.
+func Validate(conf *netv1.NetworkConfigSpec) []error {
+	switch conf.DefaultNetwork.Type {
+	case netv1.NetworkTypeOpenShiftSDN:
+		return validateOpenShiftSDN(conf)
+	case netv1.NetworkTypeOVNKubernetes:
+		return validateOVNKubernetes(conf)
+	default:
+		return []error{errors.Errorf("unknown default network type %q", conf.DefaultNetwork.Type)}
+	}
+}
+
+// IsChangeSafe reports whether moving from prev to next can be applied to a
+// running cluster without disrupting pod networking.
+func IsChangeSafe(prev, next *netv1.NetworkConfigSpec) []error {
+	if prev.DefaultNetwork.Type != next.DefaultNetwork.Type {
+		return []error{errors.Errorf("cannot change default network type")}
+	}
+
+	switch next.DefaultNetwork.Type {
+	case netv1.NetworkTypeOpenShiftSDN:
+		return isOpenShiftSDNChangeSafe(prev, next)
+	case netv1.NetworkTypeOVNKubernetes:
+		return isOVNKubernetesChangeSafe(prev, next)
+	default:
+		return []error{errors.Errorf("unknown default network type %q", next.DefaultNetwork.Type)}
+	}
+}
+
+// FillDefaults mutates conf in place, filling in any field left unset by the
+// user with its default value. previous, if non-nil, is the spec currently
+// in effect on the cluster; some defaults (like MTU) are "sticky" once
+// applied so they don't change across reconciles of an otherwise-untouched
+// field.
+func FillDefaults(conf, previous *netv1.NetworkConfigSpec) {
+	switch conf.DefaultNetwork.Type {
+	case netv1.NetworkTypeOpenShiftSDN:
+		fillOpenShiftSDNDefaults(conf, previous, defaultHostMTU)
+	case netv1.NetworkTypeOVNKubernetes:
+		fillOVNKubernetesDefaults(conf, previous, defaultHostMTU)
+	}
+}
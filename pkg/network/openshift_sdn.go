@@ -0,0 +1,243 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	yaml "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultVXLANPort is the UDP port openshift-sdn uses for its VXLAN overlay
+// unless the user overrides it.
+const defaultVXLANPort = uint32(4789)
+
+// vxlanOverhead is the number of bytes of VXLAN encapsulation overhead that
+// must be subtracted from the host interface's MTU to get a safe overlay MTU.
+const vxlanOverhead = 50
+
+// defaultOVSHealthCheckIntervalSeconds and defaultOVSHealthCheckFailureThreshold
+// are the out-of-the-box OVS liveness probe settings.
+const (
+	defaultOVSHealthCheckIntervalSeconds  = uint32(30)
+	defaultOVSHealthCheckFailureThreshold = uint32(3)
+)
+
+// renderOpenShiftSDN returns the objects that make up the openshift-sdn
+// default network: the sdn and sdn-controller DaemonSets, their RBAC, and
+// (unless UseExternalOpenvswitch is set) the OVS DaemonSet.
+func renderOpenShiftSDN(conf *netv1.NetworkConfigSpec, manifestDir string) ([]*uns.Unstructured, error) {
+	c := conf.DefaultNetwork.OpenShiftSDNConfig
+
+	sdnConfigYAML, err := sdnConfigYAML(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	data := render.MakeRenderData()
+	data.Set("Namespace", "openshift-sdn")
+	data.Set("SDNImage", os.Getenv("SDN_IMAGE"))
+	data.Set("Mode", string(c.Mode))
+	data.Set("MTU", *c.MTU)
+	data.Set("VXLANPort", *c.VXLANPort)
+	data.Set("EnableUnidling", c.EnableUnidling != nil && *c.EnableUnidling)
+	data.Set("UseExternalOpenvswitch", c.UseExternalOpenvswitch != nil && *c.UseExternalOpenvswitch)
+	data.Set("SDNConfigYAML", sdnConfigYAML)
+	data.Set("OVSHealthCheckIntervalSeconds", *c.OVSHealthCheck.IntervalSeconds)
+	data.Set("OVSHealthCheckFailureThreshold", *c.OVSHealthCheck.FailureThreshold)
+
+	objs, err := render.RenderDir(filepath.Join(manifestDir, "network", "openshift-sdn"), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render openshift-sdn manifests")
+	}
+
+	return objs, nil
+}
+
+// sdnConfigYAML builds the contents of the sdn-config ConfigMap's
+// sdn-config.yaml entry, which is mounted into the sdn DaemonSet's
+// containers.
+func sdnConfigYAML(conf *netv1.NetworkConfigSpec) (string, error) {
+	bindAddress := "0.0.0.0"
+	var iptablesSyncPeriod string
+	var proxyArguments map[string][]string
+	if pc := conf.KubeProxyConfig; pc != nil {
+		if pc.BindAddress != "" {
+			bindAddress = pc.BindAddress
+		}
+		iptablesSyncPeriod = pc.IptablesSyncPeriod
+		proxyArguments = pc.ProxyArguments
+	}
+
+	cfg := map[string]interface{}{
+		"kind":       "KubeProxyConfiguration",
+		"apiVersion": "kubeproxy.config.k8s.io/v1alpha1",
+		"servingInfo": map[string]interface{}{
+			"bindAddress": fmt.Sprintf("%s:10251", bindAddress),
+		},
+	}
+	if iptablesSyncPeriod != "" {
+		cfg["iptablesSyncPeriod"] = iptablesSyncPeriod
+	}
+	if len(proxyArguments) > 0 {
+		cfg["proxyArguments"] = proxyArguments
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal sdn-config")
+	}
+
+	return string(out), nil
+}
+
+// fillOpenShiftSDNDefaults fills in defaults for fields left unset by the
+// user. hostMTU is the MTU of the node's primary interface, used to derive
+// the overlay MTU when neither the user nor previous specifies one.
+func fillOpenShiftSDNDefaults(conf, previous *netv1.NetworkConfigSpec, hostMTU uint32) {
+	if conf.DefaultNetwork.OpenShiftSDNConfig == nil {
+		conf.DefaultNetwork.OpenShiftSDNConfig = &netv1.OpenShiftSDNConfig{}
+	}
+	sc := conf.DefaultNetwork.OpenShiftSDNConfig
+
+	if sc.Mode == "" {
+		sc.Mode = netv1.SDNModeNetworkPolicy
+	}
+
+	if sc.VXLANPort == nil {
+		port := defaultVXLANPort
+		sc.VXLANPort = &port
+	}
+
+	if sc.MTU == nil {
+		mtu := hostMTU - vxlanOverhead
+		if previous != nil && previous.DefaultNetwork.OpenShiftSDNConfig != nil &&
+			previous.DefaultNetwork.OpenShiftSDNConfig.MTU != nil {
+			mtu = *previous.DefaultNetwork.OpenShiftSDNConfig.MTU
+		}
+		sc.MTU = &mtu
+	}
+
+	if sc.EnableUnidling == nil {
+		enable := true
+		sc.EnableUnidling = &enable
+	}
+
+	if sc.OVSHealthCheck == nil {
+		sc.OVSHealthCheck = &netv1.OVSHealthCheck{}
+	}
+	if sc.OVSHealthCheck.IntervalSeconds == nil {
+		interval := defaultOVSHealthCheckIntervalSeconds
+		sc.OVSHealthCheck.IntervalSeconds = &interval
+	}
+	if sc.OVSHealthCheck.FailureThreshold == nil {
+		threshold := defaultOVSHealthCheckFailureThreshold
+		sc.OVSHealthCheck.FailureThreshold = &threshold
+	}
+
+	if conf.DeployKubeProxy == nil {
+		deploy := false
+		conf.DeployKubeProxy = &deploy
+	}
+
+	if conf.KubeProxyConfig == nil {
+		conf.KubeProxyConfig = &netv1.ProxyConfig{}
+	}
+	pc := conf.KubeProxyConfig
+
+	if pc.BindAddress == "" {
+		pc.BindAddress = "0.0.0.0"
+	}
+
+	if pc.ProxyArguments == nil {
+		pc.ProxyArguments = map[string][]string{
+			"metrics-bind-address": {"0.0.0.0:9101"},
+		}
+	}
+}
+
+// validateOpenShiftSDN checks that the configuration is internally
+// consistent, returning every problem it finds rather than stopping at the
+// first.
+func validateOpenShiftSDN(conf *netv1.NetworkConfigSpec) []error {
+	out := []error{}
+
+	if len(conf.ClusterNetworks) == 0 {
+		out = append(out, errors.Errorf("ClusterNetworks cannot be empty"))
+	}
+
+	sc := conf.DefaultNetwork.OpenShiftSDNConfig
+	if sc == nil {
+		return out
+	}
+
+	if sc.Mode != "" {
+		switch sc.Mode {
+		case netv1.SDNModeSubnet, netv1.SDNModeMultitenant, netv1.SDNModeNetworkPolicy:
+		default:
+			out = append(out, errors.Errorf("invalid openshift-sdn mode %q", sc.Mode))
+		}
+	}
+
+	if sc.MTU != nil && *sc.MTU > 65536 {
+		out = append(out, errors.Errorf("invalid MTU %d", *sc.MTU))
+	}
+
+	if sc.VXLANPort != nil && *sc.VXLANPort > 65535 {
+		out = append(out, errors.Errorf("invalid VXLANPort %d", *sc.VXLANPort))
+	}
+
+	return out
+}
+
+// isOpenShiftSDNChangeSafe determines whether it is safe to switch from the
+// prev to the next configuration while the cluster is live, i.e. without
+// disrupting running pods.
+//
+// KubeProxyConfig, EnableUnidling, and UseExternalOpenvswitch are not
+// compared, so they can be changed in place: renderOpenShiftSDN regenerates
+// the sdn-config ConfigMap from KubeProxyConfig, sets the sdn container's
+// --proxy-mode from EnableUnidling, and renders or omits the ovs DaemonSet
+// according to UseExternalOpenvswitch. Mode, MTU, VXLANPort,
+// ClusterNetworks, and ServiceNetwork all affect how the overlay itself is
+// built, so changing any of them requires tearing down and recreating the
+// network.
+func isOpenShiftSDNChangeSafe(prev, next *netv1.NetworkConfigSpec) []error {
+	pn := prev.DefaultNetwork.OpenShiftSDNConfig
+	nn := next.DefaultNetwork.OpenShiftSDNConfig
+
+	errs := []error{}
+
+	if prev.ServiceNetwork != next.ServiceNetwork {
+		errs = append(errs, errors.Errorf("cannot change ServiceNetwork"))
+	}
+	if !reflect.DeepEqual(prev.ClusterNetworks, next.ClusterNetworks) {
+		errs = append(errs, errors.Errorf("cannot change ClusterNetworks"))
+	}
+
+	if pn == nil && nn == nil {
+		return errs
+	}
+	if pn == nil || nn == nil {
+		return append(errs, errors.Errorf("cannot change openshift-sdn configuration"))
+	}
+
+	if pn.Mode != nn.Mode {
+		errs = append(errs, errors.Errorf("cannot change openshift-sdn mode"))
+	}
+	if !uint32PtrEqual(pn.MTU, nn.MTU) {
+		errs = append(errs, errors.Errorf("cannot change openshift-sdn MTU"))
+	}
+	if !uint32PtrEqual(pn.VXLANPort, nn.VXLANPort) {
+		errs = append(errs, errors.Errorf("cannot change openshift-sdn VXLANPort"))
+	}
+
+	return errs
+}
@@ -69,9 +69,35 @@ func TestRenderOpenShiftSDN(t *testing.T) {
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ServiceAccount", "openshift-sdn", "sdn")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ServiceAccount", "openshift-sdn", "sdn-controller")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRoleBinding", "", "openshift-sdn")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRoleBinding", "", "openshift-sdn-controller")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-sdn", "sdn")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-sdn", "sdn-controller")))
 
+	// the sdn-controller ServiceAccount must actually be bound to the
+	// openshift-sdn-controller ClusterRole, not just exist alongside it
+	var controllerBinding *uns.Unstructured
+	for _, obj := range objs {
+		if obj.GetKind() == "ClusterRoleBinding" && obj.GetName() == "openshift-sdn-controller" {
+			controllerBinding = obj
+		}
+	}
+	g.Expect(controllerBinding).NotTo(BeNil())
+
+	roleRefName, _, err := uns.NestedString(controllerBinding.Object, "roleRef", "name")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(roleRefName).To(Equal("openshift-sdn-controller"))
+
+	roleRefAPIGroup, _, err := uns.NestedString(controllerBinding.Object, "roleRef", "apiGroup")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(roleRefAPIGroup).To(Equal("rbac.authorization.k8s.io"))
+
+	subjects, _, err := uns.NestedSlice(controllerBinding.Object, "subjects")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(subjects).NotTo(BeEmpty())
+	subjectName, _, err := uns.NestedString(subjects[0].(map[string]interface{}), "name")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(subjectName).To(Equal("sdn-controller"))
+
 	// make sure all deployments are in the master
 	for _, obj := range objs {
 		if obj.GetKind() != "Deployment" {
@@ -111,8 +137,11 @@ func TestFillOpenShiftSDNDefaults(t *testing.T) {
 
 	// vars
 	f := false
+	t_ := true
 	p := uint32(4789)
 	m := uint32(8950)
+	interval := uint32(30)
+	threshold := uint32(3)
 
 	expected := netv1.NetworkConfigSpec{
 		ServiceNetwork: "172.30.0.0/16",
@@ -129,9 +158,14 @@ func TestFillOpenShiftSDNDefaults(t *testing.T) {
 		DefaultNetwork: netv1.DefaultNetworkDefinition{
 			Type: netv1.NetworkTypeOpenShiftSDN,
 			OpenShiftSDNConfig: &netv1.OpenShiftSDNConfig{
-				Mode:      netv1.SDNModeNetworkPolicy,
-				VXLANPort: &p,
-				MTU:       &m,
+				Mode:           netv1.SDNModeNetworkPolicy,
+				VXLANPort:      &p,
+				MTU:            &m,
+				EnableUnidling: &t_,
+				OVSHealthCheck: &netv1.OVSHealthCheck{
+					IntervalSeconds:  &interval,
+					FailureThreshold: &threshold,
+				},
 			},
 		},
 		DeployKubeProxy: &f,
@@ -147,6 +181,99 @@ func TestFillOpenShiftSDNDefaults(t *testing.T) {
 
 }
 
+// TestRenderOpenShiftSDNUnidling asserts that EnableUnidling controls which
+// proxy mode the rendered sdn DaemonSet runs with.
+func TestRenderOpenShiftSDNUnidling(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := OpenShiftSDNConfig.DeepCopy()
+	config := &crd.Spec
+	FillDefaults(config, nil)
+	sdnConfig := config.DefaultNetwork.OpenShiftSDNConfig
+
+	getSdnArgs := func(objs []*uns.Unstructured) []string {
+		for _, obj := range objs {
+			if obj.GetKind() != "DaemonSet" || obj.GetName() != "sdn" {
+				continue
+			}
+			containers, _, err := uns.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(containers).NotTo(BeEmpty())
+			args, _, err := uns.NestedStringSlice(containers[0].(map[string]interface{}), "args")
+			g.Expect(err).NotTo(HaveOccurred())
+			return args
+		}
+		t.Fatal("failed to find sdn DaemonSet")
+		return nil //unreachable
+	}
+
+	// enabled (the default): the unidling proxy mode is used
+	objs, err := renderOpenShiftSDN(config, manifestDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSdnArgs(objs)).To(ContainElement("--proxy-mode=unidling+iptables"))
+
+	// disabled: falls back to the plain iptables proxy
+	disable := false
+	sdnConfig.EnableUnidling = &disable
+	objs, err = renderOpenShiftSDN(config, manifestDir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getSdnArgs(objs)).To(ContainElement("--proxy-mode=iptables"))
+}
+
+// TestRenderOpenShiftSDNOVSHealthCheck asserts that the ovs DaemonSet gets a
+// liveness probe against the OVSDB socket, and that the sdn DaemonSet waits
+// for that same socket to appear before starting.
+func TestRenderOpenShiftSDNOVSHealthCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := OpenShiftSDNConfig.DeepCopy()
+	config := &crd.Spec
+	FillDefaults(config, nil)
+
+	objs, err := renderOpenShiftSDN(config, manifestDir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var ovs, sdn *uns.Unstructured
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" {
+			continue
+		}
+		switch obj.GetName() {
+		case "ovs":
+			ovs = obj
+		case "sdn":
+			sdn = obj
+		}
+	}
+	g.Expect(ovs).NotTo(BeNil())
+	g.Expect(sdn).NotTo(BeNil())
+
+	ovsContainers, _, err := uns.NestedSlice(ovs.Object, "spec", "template", "spec", "containers")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ovsContainers).NotTo(BeEmpty())
+	ovsContainer := ovsContainers[0].(map[string]interface{})
+
+	probeCmd, found, err := uns.NestedStringSlice(ovsContainer, "livenessProbe", "exec", "command")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(probeCmd).To(ContainElement("unix:/var/run/openvswitch/db.sock"))
+
+	// render.RenderDir decodes manifests with encoding/json, so numbers are float64
+	threshold, found, err := uns.NestedFloat64(ovsContainer, "livenessProbe", "failureThreshold")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(threshold).To(Equal(float64(3)))
+
+	sdnInitContainers, _, err := uns.NestedSlice(sdn.Object, "spec", "template", "spec", "initContainers")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sdnInitContainers).NotTo(BeEmpty())
+
+	initCmd, found, err := uns.NestedStringSlice(sdnInitContainers[0].(map[string]interface{}), "command")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(initCmd[len(initCmd)-1]).To(ContainSubstring("/var/run/openvswitch/db.sock"))
+}
+
 func TestValidateOpenShiftSDN(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -246,22 +373,104 @@ func TestProxyArgs(t *testing.T) {
 
 }
 
+// TestOpenShiftSDNIsSafe covers every field isOpenShiftSDNChangeSafe knows
+// about, both the ones that can be changed on a running cluster and the
+// ones that require a full redeploy.
 func TestOpenShiftSDNIsSafe(t *testing.T) {
-	g := NewGomegaWithT(t)
+	tests := []struct {
+		name      string
+		mutate    func(next *netv1.NetworkConfigSpec)
+		expectErr string // "" if the change should be accepted
+	}{
+		{
+			name:   "no change",
+			mutate: func(next *netv1.NetworkConfigSpec) {},
+		},
+		{
+			name: "change KubeProxyConfig",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				next.KubeProxyConfig.IptablesSyncPeriod = "1m"
+				next.KubeProxyConfig.BindAddress = "1.2.3.4"
+				next.KubeProxyConfig.ProxyArguments = map[string][]string{"x": {"y"}}
+			},
+		},
+		{
+			name: "toggle EnableUnidling",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				f := false
+				next.DefaultNetwork.OpenShiftSDNConfig.EnableUnidling = &f
+			},
+		},
+		{
+			name: "toggle UseExternalOpenvswitch",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				t := true
+				next.DefaultNetwork.OpenShiftSDNConfig.UseExternalOpenvswitch = &t
+			},
+		},
+		{
+			name: "change Mode",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				next.DefaultNetwork.OpenShiftSDNConfig.Mode = netv1.SDNModeSubnet
+			},
+			expectErr: "cannot change openshift-sdn mode",
+		},
+		{
+			name: "change MTU",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				m := uint32(1400)
+				next.DefaultNetwork.OpenShiftSDNConfig.MTU = &m
+			},
+			expectErr: "cannot change openshift-sdn MTU",
+		},
+		{
+			name: "change VXLANPort",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				p := uint32(99)
+				next.DefaultNetwork.OpenShiftSDNConfig.VXLANPort = &p
+			},
+			expectErr: "cannot change openshift-sdn VXLANPort",
+		},
+		{
+			name: "change ClusterNetworks",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				next.ClusterNetworks = append(next.ClusterNetworks, netv1.ClusterNetwork{CIDR: "10.200.0.0/16", HostSubnetLength: 8})
+			},
+			expectErr: "cannot change ClusterNetworks",
+		},
+		{
+			name: "change ServiceNetwork",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				next.ServiceNetwork = "172.31.0.0/16"
+			},
+			expectErr: "cannot change ServiceNetwork",
+		},
+		{
+			name: "OpenShiftSDNConfig goes nil",
+			mutate: func(next *netv1.NetworkConfigSpec) {
+				next.DefaultNetwork.OpenShiftSDNConfig = nil
+			},
+			expectErr: "cannot change openshift-sdn configuration",
+		},
+	}
 
-	prev := OpenShiftSDNConfig.Spec.DeepCopy()
-	FillDefaults(prev, nil)
-	next := OpenShiftSDNConfig.Spec.DeepCopy()
-	FillDefaults(next, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
 
-	errs := isOpenShiftSDNChangeSafe(prev, next)
-	g.Expect(errs).To(BeEmpty())
+			prev := OpenShiftSDNConfig.Spec.DeepCopy()
+			FillDefaults(prev, nil)
+			next := OpenShiftSDNConfig.Spec.DeepCopy()
+			FillDefaults(next, nil)
 
-	// change the vxlan port
-	p := uint32(99)
-	next.DefaultNetwork.OpenShiftSDNConfig.VXLANPort = &p
+			tt.mutate(next)
 
-	errs = isOpenShiftSDNChangeSafe(prev, next)
-	g.Expect(errs).To(HaveLen(1))
-	g.Expect(errs[0]).To(MatchError("cannot change openshift-sdn configuration"))
+			errs := isOpenShiftSDNChangeSafe(prev, next)
+			if tt.expectErr == "" {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(ContainElement(MatchError(tt.expectErr)))
+			}
+		})
+	}
 }
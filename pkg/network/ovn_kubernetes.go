@@ -0,0 +1,176 @@
+package network
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultGenevePort is the UDP port ovn-kubernetes uses for its Geneve
+// overlay unless the user overrides it.
+const defaultGenevePort = uint32(6081)
+
+// geneveOverhead is the number of bytes of Geneve encapsulation overhead
+// that must be subtracted from the host interface's MTU to get a safe
+// overlay MTU.
+const geneveOverhead = 100
+
+// renderOVNKubernetes returns the objects that make up the ovn-kubernetes
+// default network: a single ovnkube-node DaemonSet that runs the on-node
+// cniserver (and owns all OVN/OVS plumbing), plus an init container that
+// installs the cnishim binary into /opt/cni/bin so the kubelet can invoke it.
+// Unlike openshift-sdn, ovn-kubernetes needs no heavyweight CNI binary on
+// the host: the shim just forwards ADD/DEL/CHECK over a unix socket to the
+// cniserver.
+func renderOVNKubernetes(conf *netv1.NetworkConfigSpec, manifestDir string) ([]*uns.Unstructured, error) {
+	c := conf.DefaultNetwork.OVNKubernetesConfig
+
+	data := render.MakeRenderData()
+	data.Set("Namespace", "openshift-ovn-kubernetes")
+	data.Set("OVNKubernetesImage", os.Getenv("OVN_KUBERNETES_IMAGE"))
+	data.Set("MTU", *c.MTU)
+	data.Set("GenevePort", *c.GenevePort)
+
+	objs, err := render.RenderDir(filepath.Join(manifestDir, "network", "ovn-kubernetes"), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render ovn-kubernetes manifests")
+	}
+
+	return objs, nil
+}
+
+// fillOVNKubernetesDefaults fills in defaults for fields left unset by the
+// user. hostMTU is the MTU of the node's primary interface, used to derive
+// the overlay MTU when neither the user nor previous specifies one.
+func fillOVNKubernetesDefaults(conf, previous *netv1.NetworkConfigSpec, hostMTU uint32) {
+	if conf.DefaultNetwork.OVNKubernetesConfig == nil {
+		conf.DefaultNetwork.OVNKubernetesConfig = &netv1.OVNKubernetesConfig{}
+	}
+	c := conf.DefaultNetwork.OVNKubernetesConfig
+
+	if c.GenevePort == nil {
+		port := defaultGenevePort
+		c.GenevePort = &port
+	}
+
+	if c.MTU == nil {
+		mtu := hostMTU - geneveOverhead
+		if previous != nil && previous.DefaultNetwork.OVNKubernetesConfig != nil &&
+			previous.DefaultNetwork.OVNKubernetesConfig.MTU != nil {
+			mtu = *previous.DefaultNetwork.OVNKubernetesConfig.MTU
+		}
+		c.MTU = &mtu
+	}
+}
+
+// validateOVNKubernetes checks that the configuration is internally
+// consistent, returning every problem it finds rather than stopping at the
+// first.
+func validateOVNKubernetes(conf *netv1.NetworkConfigSpec) []error {
+	out := []error{}
+
+	if len(conf.ClusterNetworks) == 0 {
+		out = append(out, errors.Errorf("ClusterNetworks cannot be empty"))
+	}
+
+	c := conf.DefaultNetwork.OVNKubernetesConfig
+	if c == nil {
+		return out
+	}
+
+	if c.MTU != nil && *c.MTU > 65536 {
+		out = append(out, errors.Errorf("invalid MTU %d", *c.MTU))
+	}
+
+	if c.GenevePort != nil && *c.GenevePort > 65535 {
+		out = append(out, errors.Errorf("invalid GenevePort %d", *c.GenevePort))
+	}
+
+	if err := validateNoClusterNetworkOverlap(conf); err != nil {
+		out = append(out, err)
+	}
+
+	return out
+}
+
+// validateNoClusterNetworkOverlap makes sure the service network and every
+// cluster network CIDR are disjoint; ovn-kubernetes, unlike openshift-sdn,
+// routes all of these through the same set of OVN logical switches, so an
+// overlap would make addresses ambiguous.
+func validateNoClusterNetworkOverlap(conf *netv1.NetworkConfigSpec) error {
+	var cidrs []*net.IPNet
+
+	if conf.ServiceNetwork != "" {
+		_, svc, err := net.ParseCIDR(conf.ServiceNetwork)
+		if err != nil {
+			return errors.Wrapf(err, "invalid ServiceNetwork %q", conf.ServiceNetwork)
+		}
+		cidrs = append(cidrs, svc)
+	}
+
+	for _, cn := range conf.ClusterNetworks {
+		_, ipnet, err := net.ParseCIDR(cn.CIDR)
+		if err != nil {
+			return errors.Wrapf(err, "invalid cluster network CIDR %q", cn.CIDR)
+		}
+		cidrs = append(cidrs, ipnet)
+	}
+
+	for i := 0; i < len(cidrs); i++ {
+		for j := i + 1; j < len(cidrs); j++ {
+			if cidrsOverlap(cidrs[i], cidrs[j]) {
+				return errors.Errorf("%s and %s overlap", cidrs[i], cidrs[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// isOVNKubernetesChangeSafe determines whether it is safe to switch from the
+// prev to the next configuration while the cluster is live. ServiceNetwork,
+// ClusterNetworks, and every OVNKubernetesConfig field (MTU, GenevePort)
+// shape the overlay, so a change to any of them is rejected.
+func isOVNKubernetesChangeSafe(prev, next *netv1.NetworkConfigSpec) []error {
+	pc := prev.DefaultNetwork.OVNKubernetesConfig
+	nc := next.DefaultNetwork.OVNKubernetesConfig
+
+	errs := []error{}
+
+	if prev.ServiceNetwork != next.ServiceNetwork {
+		errs = append(errs, errors.Errorf("cannot change ServiceNetwork"))
+	}
+	if !reflect.DeepEqual(prev.ClusterNetworks, next.ClusterNetworks) {
+		errs = append(errs, errors.Errorf("cannot change ClusterNetworks"))
+	}
+
+	if pc == nil && nc == nil {
+		return errs
+	}
+	if pc == nil || nc == nil ||
+		!uint32PtrEqual(pc.MTU, nc.MTU) ||
+		!uint32PtrEqual(pc.GenevePort, nc.GenevePort) {
+		return append(errs, errors.Errorf("cannot change ovn-kubernetes configuration"))
+	}
+
+	return errs
+}
+
+func uint32PtrEqual(a, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,134 @@
+package network
+
+import (
+	"testing"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+var OVNKubernetesConfig = netv1.NetworkConfig{
+	Spec: netv1.NetworkConfigSpec{
+		ServiceNetwork: "172.30.0.0/16",
+		ClusterNetworks: []netv1.ClusterNetwork{
+			{
+				CIDR:             "10.128.0.0/15",
+				HostSubnetLength: 9,
+			},
+		},
+		DefaultNetwork: netv1.DefaultNetworkDefinition{
+			Type:                netv1.NetworkTypeOVNKubernetes,
+			OVNKubernetesConfig: &netv1.OVNKubernetesConfig{},
+		},
+	},
+}
+
+// TestRenderOVNKubernetes has some simple rendering tests, modeled on
+// TestRenderOpenShiftSDN.
+func TestRenderOVNKubernetes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := OVNKubernetesConfig.DeepCopy()
+	config := &crd.Spec
+
+	errs := validateOVNKubernetes(config)
+	g.Expect(errs).To(HaveLen(0))
+	FillDefaults(config, nil)
+
+	objs, err := renderOVNKubernetes(config, manifestDir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(objs[0]).To(HaveKubernetesID("Namespace", "", "openshift-ovn-kubernetes"))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRole", "", "openshift-ovn-kubernetes")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ServiceAccount", "openshift-ovn-kubernetes", "ovn-kubernetes-node")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRoleBinding", "", "openshift-ovn-kubernetes")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-ovn-kubernetes", "ovnkube-node")))
+
+	// the roleRef must carry an apiGroup, or a real apiserver rejects the
+	// binding outright with "roleRef.apiGroup: Required value"
+	var binding *uns.Unstructured
+	for _, obj := range objs {
+		if obj.GetKind() == "ClusterRoleBinding" {
+			binding = obj
+		}
+	}
+	g.Expect(binding).NotTo(BeNil())
+
+	roleRefAPIGroup, _, err := uns.NestedString(binding.Object, "roleRef", "apiGroup")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(roleRefAPIGroup).To(Equal("rbac.authorization.k8s.io"))
+
+	for _, obj := range objs {
+		g.Expect(apply.IsObjectSupported(obj)).NotTo(HaveOccurred())
+		cur := obj.DeepCopy()
+		upd := obj.DeepCopy()
+
+		err = apply.MergeObjectForUpdate(cur, upd)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		tweakMetaForCompare(cur)
+		g.Expect(cur).To(Equal(upd))
+	}
+}
+
+// TestValidateOVNKubernetes is modeled on TestValidateOpenShiftSDN.
+func TestValidateOVNKubernetes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := OVNKubernetesConfig.DeepCopy()
+	config := &crd.Spec
+	c := config.DefaultNetwork.OVNKubernetesConfig
+
+	err := validateOVNKubernetes(config)
+	g.Expect(err).To(BeEmpty())
+	FillDefaults(config, nil)
+
+	errExpect := func(substr string) {
+		t.Helper()
+		g.Expect(validateOVNKubernetes(config)).To(
+			ContainElement(MatchError(
+				ContainSubstring(substr))))
+	}
+
+	mtu := uint32(70000)
+	c.MTU = &mtu
+	errExpect("invalid MTU 70000")
+
+	port := uint32(66666)
+	c.GenevePort = &port
+	errExpect("invalid GenevePort 66666")
+
+	config.ClusterNetworks = nil
+	errExpect("ClusterNetworks cannot be empty")
+
+	// an overlapping service/cluster network should be rejected
+	crd = OVNKubernetesConfig.DeepCopy()
+	config = &crd.Spec
+	FillDefaults(config, nil)
+	config.ServiceNetwork = "10.128.0.0/24"
+	errExpect("overlap")
+}
+
+// TestOVNKubernetesIsSafe is modeled on TestOpenShiftSDNIsSafe.
+func TestOVNKubernetesIsSafe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	prev := OVNKubernetesConfig.Spec.DeepCopy()
+	FillDefaults(prev, nil)
+	next := OVNKubernetesConfig.Spec.DeepCopy()
+	FillDefaults(next, nil)
+
+	errs := isOVNKubernetesChangeSafe(prev, next)
+	g.Expect(errs).To(BeEmpty())
+
+	port := uint32(9999)
+	next.DefaultNetwork.OVNKubernetesConfig.GenevePort = &port
+
+	errs = isOVNKubernetesChangeSafe(prev, next)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0]).To(MatchError("cannot change ovn-kubernetes configuration"))
+}
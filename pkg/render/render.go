@@ -0,0 +1,125 @@
+// Package render turns directories of Go-templated Kubernetes manifests into
+// unstructured objects.
+package render
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	yaml "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderData is the set of values a manifest template can reference.
+type RenderData struct {
+	data map[string]interface{}
+}
+
+// MakeRenderData returns a new, empty RenderData.
+func MakeRenderData() RenderData {
+	return RenderData{data: map[string]interface{}{}}
+}
+
+// Set adds or replaces the named value available to templates.
+func (d *RenderData) Set(name string, value interface{}) {
+	d.data[name] = value
+}
+
+// RenderDir renders every *.yaml/*.yml template under dir, in deterministic
+// (path-sorted) order, and returns the resulting objects in document order.
+// A template may omit an object entirely with a top-level {{if}}: documents
+// that render to nothing but whitespace are silently dropped.
+func RenderDir(dir string, data *RenderData) ([]*uns.Unstructured, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml":
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var out []*uns.Unstructured
+	for _, path := range paths {
+		objs, err := renderFile(path, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render %s", path)
+		}
+		out = append(out, objs...)
+	}
+
+	return out, nil
+}
+
+// funcMap holds the small set of helpers manifest templates can call. We
+// deliberately keep this minimal rather than pulling in sprig wholesale.
+var funcMap = template.FuncMap{
+	"indent": indent,
+}
+
+// indent prefixes every line of s with n spaces, for embedding a block of
+// YAML (e.g. a rendered config file) inside another manifest.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		lines[i] = pad + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderFile(path string, data *RenderData) ([]*uns.Unstructured, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(funcMap).Parse(string(raw))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data.data); err != nil {
+		return nil, errors.Wrap(err, "failed to render template")
+	}
+
+	var objs []*uns.Unstructured
+	for _, doc := range strings.Split(buf.String(), "\n---\n") {
+		if len(strings.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj := uns.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal manifest from %s", path)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		objs = append(objs, &obj)
+	}
+
+	return objs, nil
+}
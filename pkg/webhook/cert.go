@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// certValidity is how long a generated CA/server certificate pair is valid
+// for. Nothing in this package renews a pair before it expires; a new pair
+// exists only when GenerateSelfSignedCerts is called again.
+const certValidity = 2 * 365 * 24 * time.Hour
+
+// GeneratedCerts holds a freshly minted, self-signed CA and the server
+// certificate (signed by that CA) the webhook listens with. The CA
+// certificate is what gets published into the ValidatingWebhookConfiguration
+// so the API server trusts the server certificate.
+type GeneratedCerts struct {
+	CACertPEM     []byte
+	ServerCertPEM []byte
+	ServerKeyPEM  []byte
+}
+
+// GenerateSelfSignedCerts creates a new CA and a server certificate for
+// dnsName (typically "<service>.<namespace>.svc"), both valid for
+// certValidity. Calling it again produces an entirely new, independent
+// CA/server pair; this package does not call it on any schedule.
+func GenerateSelfSignedCerts(dnsName string) (*GeneratedCerts, error) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA key")
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "network-operator-webhook-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caCertDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to self-sign CA certificate")
+	}
+	caCert, err := x509.ParseCertificate(caCertDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate server key")
+	}
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverCertDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign server certificate")
+	}
+
+	return &GeneratedCerts{
+		CACertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCertDER}),
+		ServerCertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCertDER}),
+		ServerKeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(serverKey)}),
+	}, nil
+}
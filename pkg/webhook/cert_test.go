@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// TestGenerateSelfSignedCerts asserts that the returned CA and server
+// certificate are well-formed PEM, that the server certificate is actually
+// signed by the CA (so the CABundle published in the
+// ValidatingWebhookConfiguration is the one the apiserver needs to trust the
+// server certificate), and that the server certificate covers dnsName.
+func TestGenerateSelfSignedCerts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	certs, err := GenerateSelfSignedCerts("network-operator-webhook.openshift-network-operator.svc")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	caBlock, _ := pem.Decode(certs.CACertPEM)
+	g.Expect(caBlock).NotTo(BeNil())
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(caCert.IsCA).To(BeTrue())
+
+	serverBlock, _ := pem.Decode(certs.ServerCertPEM)
+	g.Expect(serverBlock).NotTo(BeNil())
+	serverCert, err := x509.ParseCertificate(serverBlock.Bytes)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(serverCert.DNSNames).To(ContainElement("network-operator-webhook.openshift-network-operator.svc"))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	_, err = serverCert.Verify(x509.VerifyOptions{
+		DNSName:   "network-operator-webhook.openshift-network-operator.svc",
+		Roots:     pool,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	keyBlock, _ := pem.Decode(certs.ServerKeyPEM)
+	g.Expect(keyBlock).NotTo(BeNil())
+	g.Expect(keyBlock.Type).To(Equal("RSA PRIVATE KEY"))
+}
+
+// TestGenerateSelfSignedCertsIsFreshEachCall asserts that calling
+// GenerateSelfSignedCerts again produces an independent CA/server pair,
+// which is what lets the operator rotate the webhook's certs by simply
+// calling it again and re-rendering the Secret.
+func TestGenerateSelfSignedCertsIsFreshEachCall(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	first, err := GenerateSelfSignedCerts("network-operator-webhook.openshift-network-operator.svc")
+	g.Expect(err).NotTo(HaveOccurred())
+	second, err := GenerateSelfSignedCerts("network-operator-webhook.openshift-network-operator.svc")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(first.CACertPEM).NotTo(Equal(second.CACertPEM))
+	g.Expect(first.ServerCertPEM).NotTo(Equal(second.ServerCertPEM))
+}
@@ -0,0 +1,123 @@
+// Package webhook implements a ValidatingWebhookConfiguration admission
+// handler for the NetworkConfig CRD, so users get synchronous rejection on
+// `kubectl apply` instead of a silent operator-log error.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+)
+
+// Handler admits or rejects NetworkConfig create/update requests by running
+// them through the same Validate/IsChangeSafe logic the operator itself
+// uses before reconciling.
+type Handler struct{}
+
+// NewHandler returns a ready-to-use Handler.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// ServeHTTP implements http.Handler by decoding the AdmissionReview in the
+// request body and writing back a populated one.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.review(review.Request)
+	review.Response.UID = review.Request.UID
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(review)
+}
+
+// review runs validateOpenShiftSDN (via network.Validate) against the
+// incoming object, and isOpenShiftSDNChangeSafe (via network.IsChangeSafe)
+// against the previous object on updates.
+func (h *Handler) review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	crd := netv1.NetworkConfig{}
+	if err := json.Unmarshal(req.Object.Raw, &crd); err != nil {
+		return deny(fmt.Sprintf("failed to decode NetworkConfig: %v", err), "")
+	}
+
+	if errs := network.Validate(&crd.Spec); len(errs) > 0 {
+		return deny(errs[0].Error(), fieldPathFor(crd.Spec.DefaultNetwork.Type, errs[0]))
+	}
+
+	if len(req.OldObject.Raw) > 0 {
+		old := netv1.NetworkConfig{}
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return deny(fmt.Sprintf("failed to decode previous NetworkConfig: %v", err), "")
+		}
+
+		// A stored NetworkConfig can legitimately have left a whole
+		// sub-config (e.g. openshiftSDNConfig) unset; fill in the same
+		// defaults the operator would apply before reconciling so that
+		// nil-vs-defaulted is never mistaken for a disruptive change.
+		network.FillDefaults(&old.Spec, nil)
+		network.FillDefaults(&crd.Spec, &old.Spec)
+
+		if errs := network.IsChangeSafe(&old.Spec, &crd.Spec); len(errs) > 0 {
+			return deny(errs[0].Error(), "")
+		}
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// deny builds a rejecting AdmissionResponse carrying message as both the
+// top-level Result.Message (what `kubectl apply` prints) and a StatusCause
+// pointing at field, when we know which one is at fault.
+func deny(message, field string) *admissionv1.AdmissionResponse {
+	cause := metav1.StatusCause{Message: message}
+	if field != "" {
+		cause.Field = field
+	}
+
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Message: message,
+			Details: &metav1.StatusDetails{
+				Causes: []metav1.StatusCause{cause},
+			},
+		},
+	}
+}
+
+// fieldPathFor maps a validation error to the spec field path that caused
+// it, so the rejection message in `kubectl apply -o yaml` output points
+// straight at the offending line.
+func fieldPathFor(networkType netv1.NetworkType, err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid MTU"):
+		if networkType == netv1.NetworkTypeOVNKubernetes {
+			return ".spec.defaultNetwork.ovnKubernetesConfig.mtu"
+		}
+		return ".spec.defaultNetwork.openshiftSDNConfig.mtu"
+	case strings.Contains(msg, "invalid openshift-sdn mode"):
+		return ".spec.defaultNetwork.openshiftSDNConfig.mode"
+	case strings.Contains(msg, "invalid VXLANPort"):
+		return ".spec.defaultNetwork.openshiftSDNConfig.vxlanPort"
+	case strings.Contains(msg, "ClusterNetworks cannot be empty"):
+		return ".spec.clusterNetworks"
+	default:
+		return ""
+	}
+}
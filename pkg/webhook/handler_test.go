@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/onsi/gomega"
+
+	netv1 "github.com/openshift/cluster-network-operator/pkg/apis/networkoperator/v1"
+)
+
+// baseNetworkConfig mirrors the fixture used by
+// network.TestValidateOpenShiftSDN, so the same invalid-MTU / invalid-mode /
+// invalid-VXLANPort / empty-ClusterNetworks cases are covered here at the
+// HTTP boundary.
+func baseNetworkConfig() netv1.NetworkConfig {
+	return netv1.NetworkConfig{
+		Spec: netv1.NetworkConfigSpec{
+			ServiceNetwork: "172.30.0.0/16",
+			ClusterNetworks: []netv1.ClusterNetwork{
+				{CIDR: "10.128.0.0/15", HostSubnetLength: 9},
+			},
+			DefaultNetwork: netv1.DefaultNetworkDefinition{
+				Type: netv1.NetworkTypeOpenShiftSDN,
+				OpenShiftSDNConfig: &netv1.OpenShiftSDNConfig{
+					Mode: netv1.SDNModeNetworkPolicy,
+				},
+			},
+		},
+	}
+}
+
+func postReview(t *testing.T, h http.Handler, crd netv1.NetworkConfig) *admissionv1.AdmissionResponse {
+	t.Helper()
+	return postUpdateReview(t, h, nil, crd)
+}
+
+// postUpdateReview posts an AdmissionReview for crd, including old as
+// req.OldObject when it is non-nil, so the isOpenShiftSDNChangeSafe/
+// isOVNKubernetesChangeSafe update path gets exercised the same way the
+// apiserver would on a real UPDATE request.
+func postUpdateReview(t *testing.T, h http.Handler, old *netv1.NetworkConfig, crd netv1.NetworkConfig) *admissionv1.AdmissionResponse {
+	t.Helper()
+
+	raw, err := json.Marshal(crd)
+	if err != nil {
+		t.Fatalf("failed to marshal NetworkConfig: %v", err)
+	}
+
+	req := &admissionv1.AdmissionRequest{
+		UID:    "test-uid",
+		Object: runtime.RawExtension{Raw: raw},
+	}
+
+	if old != nil {
+		oldRaw, err := json.Marshal(old)
+		if err != nil {
+			t.Fatalf("failed to marshal previous NetworkConfig: %v", err)
+		}
+		req.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+
+	review := admissionv1.AdmissionReview{Request: req}
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("failed to marshal AdmissionReview: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	out := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal AdmissionReview response: %v", err)
+	}
+
+	return out.Response
+}
+
+func TestWebhookRejectsInvalidMTU(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := baseNetworkConfig()
+	mtu := uint32(70000)
+	crd.Spec.DefaultNetwork.OpenShiftSDNConfig.MTU = &mtu
+
+	resp := postReview(t, NewHandler(), crd)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("invalid MTU 70000"))
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal(".spec.defaultNetwork.openshiftSDNConfig.mtu"))
+}
+
+func TestWebhookRejectsInvalidMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := baseNetworkConfig()
+	crd.Spec.DefaultNetwork.OpenShiftSDNConfig.Mode = "broken"
+
+	resp := postReview(t, NewHandler(), crd)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring(`invalid openshift-sdn mode "broken"`))
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal(".spec.defaultNetwork.openshiftSDNConfig.mode"))
+}
+
+func TestWebhookRejectsInvalidVXLANPort(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := baseNetworkConfig()
+	port := uint32(66666)
+	crd.Spec.DefaultNetwork.OpenShiftSDNConfig.VXLANPort = &port
+
+	resp := postReview(t, NewHandler(), crd)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("invalid VXLANPort 66666"))
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal(".spec.defaultNetwork.openshiftSDNConfig.vxlanPort"))
+}
+
+func TestWebhookRejectsEmptyClusterNetworks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := baseNetworkConfig()
+	crd.Spec.ClusterNetworks = nil
+
+	resp := postReview(t, NewHandler(), crd)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("ClusterNetworks cannot be empty"))
+	g.Expect(resp.Result.Details.Causes[0].Field).To(Equal(".spec.clusterNetworks"))
+}
+
+func TestWebhookAllowsValidConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	resp := postReview(t, NewHandler(), baseNetworkConfig())
+	g.Expect(resp.Allowed).To(BeTrue())
+}
+
+// TestWebhookAllowsUpdateWithNilOpenShiftSDNConfig covers the UPDATE path:
+// a live NetworkConfig with openshiftSDNConfig unset is a state
+// validateOpenShiftSDN already treats as valid, so the handler must accept
+// the update too: it fills in defaults on both objects before calling
+// isOpenShiftSDNChangeSafe, which would otherwise reject nil-vs-set.
+func TestWebhookAllowsUpdateWithNilOpenShiftSDNConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := baseNetworkConfig()
+	old.Spec.DefaultNetwork.OpenShiftSDNConfig = nil
+
+	resp := postUpdateReview(t, NewHandler(), &old, baseNetworkConfig())
+	g.Expect(resp.Allowed).To(BeTrue())
+}
+
+// TestWebhookRejectsUpdateToOpenShiftSDNMode covers the UPDATE path where
+// both old and new objects have an OpenShiftSDNConfig, but a disruptive
+// field changed.
+func TestWebhookRejectsUpdateToOpenShiftSDNMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	old := baseNetworkConfig()
+	next := baseNetworkConfig()
+	next.Spec.DefaultNetwork.OpenShiftSDNConfig.Mode = netv1.SDNModeSubnet
+
+	resp := postUpdateReview(t, NewHandler(), &old, next)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Message).To(ContainSubstring("cannot change openshift-sdn mode"))
+}
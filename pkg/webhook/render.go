@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Namespace is where the webhook's Deployment, Service, and Secret live.
+const Namespace = "openshift-network-operator"
+
+// ServiceName is the name of the Service fronting the webhook, its
+// Deployment, and its ValidatingWebhookConfiguration. The Secret holding its
+// TLS certificate is named ServiceName + "-certs".
+const ServiceName = "network-operator-webhook"
+
+// RenderManifests returns the webhook's Namespace, Secret, Service,
+// Deployment, and ValidatingWebhookConfiguration, with certs baked in: the
+// CA as the CABundle and the server certificate and key in the Secret.
+func RenderManifests(manifestDir string, certs *GeneratedCerts) ([]*uns.Unstructured, error) {
+	data := render.MakeRenderData()
+	data.Set("Namespace", Namespace)
+	data.Set("ServiceName", ServiceName)
+	data.Set("WebhookImage", os.Getenv("NETWORK_OPERATOR_IMAGE"))
+	data.Set("CABundle", base64.StdEncoding.EncodeToString(certs.CACertPEM))
+	data.Set("ServerCert", base64.StdEncoding.EncodeToString(certs.ServerCertPEM))
+	data.Set("ServerKey", base64.StdEncoding.EncodeToString(certs.ServerKeyPEM))
+
+	objs, err := render.RenderDir(filepath.Join(manifestDir, "webhook"), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render webhook manifests")
+	}
+
+	return objs, nil
+}
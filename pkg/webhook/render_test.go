@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+var manifestDir = "../../bindata"
+
+// findObj returns the first object in objs matching kind/namespace/name, or
+// nil if there isn't one.
+func findObj(objs []*uns.Unstructured, kind, namespace, name string) *uns.Unstructured {
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetNamespace() == namespace && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+// tweakMetaForCompare strips metadata fields that apply.MergeObjectForUpdate
+// intentionally leaves untouched when the object didn't have them to begin
+// with (e.g. an empty creationTimestamp), so that comparing a freshly
+// rendered object against itself after a merge round-trip reports equal.
+func tweakMetaForCompare(obj *uns.Unstructured) {
+	meta, found, _ := uns.NestedMap(obj.Object, "metadata")
+	if !found {
+		return
+	}
+
+	if ts, ok := meta["creationTimestamp"]; ok && ts == nil {
+		delete(meta, "creationTimestamp")
+		_ = uns.SetNestedMap(obj.Object, meta, "metadata")
+	}
+}
+
+// TestRenderManifests has some simple rendering tests, modeled on
+// TestRenderOpenShiftSDN.
+func TestRenderManifests(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	certs, err := GenerateSelfSignedCerts(ServiceName + "." + Namespace + ".svc")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	objs, err := RenderManifests(manifestDir, certs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(objs[0].GetKind()).To(Equal("Namespace"))
+	g.Expect(objs[0].GetName()).To(Equal(Namespace))
+
+	secret := findObj(objs, "Secret", Namespace, ServiceName+"-certs")
+	g.Expect(secret).NotTo(BeNil())
+	g.Expect(findObj(objs, "Service", Namespace, ServiceName)).NotTo(BeNil())
+	g.Expect(findObj(objs, "Deployment", Namespace, ServiceName)).NotTo(BeNil())
+	webhookConfig := findObj(objs, "ValidatingWebhookConfiguration", "", ServiceName)
+	g.Expect(webhookConfig).NotTo(BeNil())
+
+	// the CABundle published in the webhook configuration must be the CA
+	// that actually signed the server certificate baked into the Secret
+	webhooks, _, err := uns.NestedSlice(webhookConfig.Object, "webhooks")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(webhooks).NotTo(BeEmpty())
+
+	caBundle, found, err := uns.NestedString(webhooks[0].(map[string]interface{}), "clientConfig", "caBundle")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(caBundle).To(Equal(base64.StdEncoding.EncodeToString(certs.CACertPEM)))
+
+	tlsCert, found, err := uns.NestedString(secret.Object, "data", "tls.crt")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(tlsCert).To(Equal(base64.StdEncoding.EncodeToString(certs.ServerCertPEM)))
+
+	tlsKey, found, err := uns.NestedString(secret.Object, "data", "tls.key")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(tlsKey).To(Equal(base64.StdEncoding.EncodeToString(certs.ServerKeyPEM)))
+
+	// Make sure every obj is reasonable:
+	// - it is supported
+	// - it reconciles to itself (steady state)
+	for _, obj := range objs {
+		g.Expect(apply.IsObjectSupported(obj)).NotTo(HaveOccurred())
+		cur := obj.DeepCopy()
+		upd := obj.DeepCopy()
+
+		err = apply.MergeObjectForUpdate(cur, upd)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		tweakMetaForCompare(cur)
+		g.Expect(cur).To(Equal(upd))
+	}
+}